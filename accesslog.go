@@ -0,0 +1,147 @@
+// accesslog.go
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultAccessLogFormat mirrors Apache's Common Log Format plus %D
+// (request duration in microseconds).
+const DefaultAccessLogFormat = `%h %l %u %t "%r" %s %b %D`
+
+// SetAccessLogFormat overrides the format string used by the access log
+// middleware. See DefaultAccessLogFormat for the supported tokens: %h %l %u
+// %t %r %s %b %D, plus %{Header}i / %{Header}o for arbitrary request and
+// response headers.
+func (a *App) SetAccessLogFormat(format string) {
+	a.accessLogFormat = format
+}
+
+// SetAccessLogWriter redirects access log lines to w. Defaults to os.Stdout.
+func (a *App) SetAccessLogWriter(w io.Writer) {
+	a.accessLogOut = w
+}
+
+func (a *App) accessLogFmt() string {
+	if a.accessLogFormat == "" {
+		return DefaultAccessLogFormat
+	}
+	return a.accessLogFormat
+}
+
+func (a *App) accessLogWriter() io.Writer {
+	if a.accessLogOut == nil {
+		return os.Stdout
+	}
+	return a.accessLogOut
+}
+
+// statusRecorder wraps a ResponseWriter to capture the status code and byte
+// count the access log middleware needs after the handler has already
+// written the response.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (w *statusRecorder) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusRecorder) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += n
+	return n, err
+}
+
+// accessLogMiddleware emits one line per request in a.accessLogFmt() to
+// a.accessLogWriter(), in the style of Apache's mod_log_config.
+func (a *App) accessLogMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(rec, r)
+
+		fmt.Fprintln(a.accessLogWriter(), formatAccessLogLine(a.accessLogFmt(), r, rec, start, time.Since(start)))
+	})
+}
+
+func formatAccessLogLine(format string, r *http.Request, rec *statusRecorder, start time.Time, dur time.Duration) string {
+	var b strings.Builder
+
+	for i := 0; i < len(format); i++ {
+		c := format[i]
+		if c != '%' || i == len(format)-1 {
+			b.WriteByte(c)
+			continue
+		}
+
+		i++
+		switch format[i] {
+		case 'h':
+			b.WriteString(remoteHost(r))
+		case 'l', 'u':
+			b.WriteByte('-')
+		case 't':
+			b.WriteString("[" + start.Format("02/Jan/2006:15:04:05 -0700") + "]")
+		case 'r':
+			fmt.Fprintf(&b, "%s %s %s", r.Method, r.URL.RequestURI(), r.Proto)
+		case 's':
+			b.WriteString(strconv.Itoa(rec.status))
+		case 'b':
+			if rec.bytes == 0 {
+				b.WriteByte('-')
+			} else {
+				b.WriteString(strconv.Itoa(rec.bytes))
+			}
+		case 'D':
+			b.WriteString(strconv.FormatInt(dur.Microseconds(), 10))
+		case '{':
+			end := strings.IndexByte(format[i:], '}')
+			if end == -1 || i+end+1 >= len(format) {
+				b.WriteString("%{")
+				continue
+			}
+
+			name := format[i+1 : i+end]
+			kind := format[i+end+1]
+			i += end + 1
+
+			switch kind {
+			case 'i':
+				b.WriteString(r.Header.Get(name))
+			case 'o':
+				b.WriteString(rec.Header().Get(name))
+			default:
+				fmt.Fprintf(&b, "%%{%s}%c", name, kind)
+			}
+		default:
+			b.WriteByte('%')
+			b.WriteByte(format[i])
+		}
+	}
+
+	return b.String()
+}
+
+func remoteHost(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
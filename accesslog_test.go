@@ -0,0 +1,50 @@
+// accesslog_test.go
+
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestAccessLogDefaultFormat(t *testing.T) {
+	clearTable()
+
+	var buf bytes.Buffer
+	a.SetAccessLogWriter(&buf)
+	defer a.SetAccessLogWriter(nil)
+
+	req, _ := http.NewRequest("GET", "/products", nil)
+	executeRequest(req)
+
+	line := buf.String()
+	if !strings.Contains(line, `"GET /products HTTP/1.1"`) {
+		t.Errorf("Expected the request line in the log output. Got %q", line)
+	}
+	if !strings.Contains(line, " 200 ") {
+		t.Errorf("Expected status 200 in the log output. Got %q", line)
+	}
+}
+
+func TestAccessLogCustomFormatAndHeaders(t *testing.T) {
+	clearTable()
+
+	var buf bytes.Buffer
+	a.SetAccessLogWriter(&buf)
+	a.SetAccessLogFormat(`%s %{X-Request-Id}i %{Content-Type}o`)
+	defer func() {
+		a.SetAccessLogWriter(nil)
+		a.SetAccessLogFormat("")
+	}()
+
+	req, _ := http.NewRequest("GET", "/products", nil)
+	req.Header.Set("X-Request-Id", "abc-123")
+	executeRequest(req)
+
+	expected := "200 abc-123 application/json\n"
+	if buf.String() != expected {
+		t.Errorf("Expected %q. Got %q", expected, buf.String())
+	}
+}
@@ -0,0 +1,393 @@
+// app.go
+
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	_ "github.com/lib/pq"
+)
+
+// App wires the HTTP router and the shared ProductService to a PostgreSQL
+// connection pool. REST handlers here and the gRPC server in grpc.go both
+// operate through Products, so they stay behaviourally identical.
+type App struct {
+	Router   *mux.Router
+	DB       *sql.DB
+	Products *ProductService
+	Cart     *CartService
+
+	// ReadTimeout, WriteTimeout and IdleTimeout configure the http.Server
+	// started by Run. Zero means no timeout, matching net/http's default.
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	IdleTimeout  time.Duration
+
+	// HandlerTimeout bounds how long a single request's handler may run.
+	// Zero disables the per-request deadline.
+	HandlerTimeout time.Duration
+
+	accessLogFormat string
+	accessLogOut    io.Writer
+
+	server *http.Server
+}
+
+func (a *App) Initialize(user, password, dbname string) {
+	connectionString := fmt.Sprintf("user=%s password=%s dbname=%s sslmode=disable", user, password, dbname)
+
+	var err error
+	a.DB, err = sql.Open("postgres", connectionString)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	a.Products = NewProductService(a.DB)
+	a.Cart = NewCartService(a.DB)
+
+	a.Router = mux.NewRouter()
+	a.Router.Use(a.accessLogMiddleware)
+	a.Router.Use(a.handlerTimeoutMiddleware)
+	a.initializeRoutes()
+}
+
+// Run starts an http.Server with the configured Read/Write/IdleTimeout and
+// blocks until it stops. After a Shutdown call it returns normally; any other
+// server error is fatal.
+func (a *App) Run(addr string) {
+	a.server = &http.Server{
+		Addr:         addr,
+		Handler:      a.Router,
+		ReadTimeout:  a.ReadTimeout,
+		WriteTimeout: a.WriteTimeout,
+		IdleTimeout:  a.IdleTimeout,
+	}
+
+	if err := a.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Fatal(err)
+	}
+}
+
+// Shutdown gracefully drains in-flight requests against ctx's deadline, then
+// closes the DB pool. Run returns once the server has stopped.
+func (a *App) Shutdown(ctx context.Context) error {
+	if a.server != nil {
+		if err := a.server.Shutdown(ctx); err != nil {
+			return err
+		}
+	}
+
+	return a.DB.Close()
+}
+
+func (a *App) initializeRoutes() {
+	a.Router.HandleFunc("/products", a.getProducts).Methods("GET")
+	a.Router.HandleFunc("/products/bulk", a.bulkImportProducts).Methods("POST")
+	a.Router.HandleFunc("/product", a.createProduct).Methods("POST")
+	a.Router.HandleFunc("/product/{id:[0-9]+}", a.getProduct).Methods("GET")
+	a.Router.HandleFunc("/product/{id:[0-9]+}", a.updateProduct).Methods("PUT")
+	a.Router.HandleFunc("/product/{id:[0-9]+}", a.deleteProduct).Methods("DELETE")
+	a.Router.HandleFunc("/product/{id:[0-9]+}/discount", a.discountProduct).
+		Methods("PUT").
+		Queries("discount", "{discount}")
+
+	a.Router.HandleFunc("/cart", a.getCart).Methods("GET")
+	a.Router.HandleFunc("/cart/item", a.addCartItem).Methods("POST")
+	a.Router.HandleFunc("/cart/item/{product_id:[0-9]+}", a.updateCartItem).Methods("PUT")
+	a.Router.HandleFunc("/cart/item/{product_id:[0-9]+}", a.removeCartItem).Methods("DELETE")
+	a.Router.HandleFunc("/cart/checkout", a.checkoutCart).Methods("POST")
+}
+
+func (a *App) getProduct(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	p, err := a.Products.Get(r.Context(), id)
+	if err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			respondWithError(w, http.StatusNotFound, "Product not found")
+		default:
+			respondWithError(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, p)
+}
+
+func (a *App) getProducts(w http.ResponseWriter, r *http.Request) {
+	minPrice, _ := strconv.ParseFloat(r.FormValue("min_price"), 64)
+	maxPrice, err := strconv.ParseFloat(r.FormValue("max_price"), 64)
+	if err != nil {
+		maxPrice = math.MaxFloat64
+	}
+
+	sort := r.FormValue("sort")
+	if sort == "" {
+		sort = "id"
+	}
+
+	order := r.FormValue("order")
+	if order == "" {
+		order = "asc"
+	}
+
+	limit, _ := strconv.Atoi(r.FormValue("limit"))
+
+	cursor, err := decodeProductCursor(r.FormValue("cursor"), sort)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid cursor")
+		return
+	}
+
+	page, err := a.Products.ListPage(r.Context(), productListParams{
+		MinPrice: minPrice,
+		MaxPrice: maxPrice,
+		NameLike: r.FormValue("name_like"),
+		Sort:     sort,
+		Order:    order,
+		Limit:    limit,
+		Cursor:   cursor,
+	})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"data":        page.Data,
+		"next_cursor": page.NextCursor,
+	})
+}
+
+func (a *App) bulkImportProducts(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	items, validationErrs, err := decodeBulkProducts(r.Body)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	if len(validationErrs) > 0 {
+		respondWithJSON(w, http.StatusUnprocessableEntity, map[string]interface{}{"errors": validationErrs})
+		return
+	}
+
+	ids, err := a.Products.BulkCreate(r.Context(), items)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, map[string]interface{}{"inserted": len(ids), "ids": ids})
+}
+
+func (a *App) createProduct(w http.ResponseWriter, r *http.Request) {
+	var p product
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&p); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	if err := a.Products.Create(r.Context(), &p); err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, p)
+}
+
+func (a *App) updateProduct(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	var p product
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&p); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+	p.ID = id
+
+	if err := a.Products.Update(r.Context(), &p); err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, p)
+}
+
+func (a *App) deleteProduct(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid Product ID")
+		return
+	}
+
+	if err := a.Products.Delete(r.Context(), id); err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]string{"result": "success"})
+}
+
+func (a *App) discountProduct(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	discount, err := strconv.ParseFloat(vars["discount"], 64)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid discount")
+		return
+	}
+
+	p, err := a.Products.ApplyDiscount(r.Context(), id, discount)
+	if err != nil {
+		switch err {
+		case ErrInvalidDiscount:
+			respondWithError(w, http.StatusBadRequest, "Discount must be >= 0 and <= 100")
+		case sql.ErrNoRows:
+			respondWithError(w, http.StatusNotFound, "Product not found")
+		default:
+			respondWithError(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, p)
+}
+
+func (a *App) getCart(w http.ResponseWriter, r *http.Request) {
+	c, err := a.Cart.Get(r.Context())
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, c)
+}
+
+func (a *App) addCartItem(w http.ResponseWriter, r *http.Request) {
+	var payload struct {
+		ProductID int `json:"product_id"`
+		Quantity  int `json:"quantity"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	if err := a.Cart.AddItem(r.Context(), payload.ProductID, payload.Quantity); err != nil {
+		switch err {
+		case errInvalidQuantity:
+			respondWithError(w, http.StatusBadRequest, err.Error())
+		case sql.ErrNoRows:
+			respondWithError(w, http.StatusNotFound, "Product not found")
+		default:
+			respondWithError(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	a.getCart(w, r)
+}
+
+func (a *App) updateCartItem(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	productID, err := strconv.Atoi(vars["product_id"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	var payload struct {
+		Quantity int `json:"quantity"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	if err := a.Cart.UpdateItem(r.Context(), productID, payload.Quantity); err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	a.getCart(w, r)
+}
+
+func (a *App) removeCartItem(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	productID, err := strconv.Atoi(vars["product_id"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	if err := a.Cart.RemoveItem(r.Context(), productID); err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	a.getCart(w, r)
+}
+
+func (a *App) checkoutCart(w http.ResponseWriter, r *http.Request) {
+	receipt, err := a.Cart.Checkout(r.Context())
+	if err != nil {
+		switch err {
+		case errCartEmpty:
+			respondWithError(w, http.StatusBadRequest, err.Error())
+		case errInsufficientStock:
+			respondWithError(w, http.StatusConflict, err.Error())
+		default:
+			respondWithError(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, receipt)
+}
+
+func respondWithError(w http.ResponseWriter, code int, message string) {
+	respondWithJSON(w, code, map[string]string{"error": message})
+}
+
+func respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
+	response, _ := json.Marshal(payload)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(response)
+}
@@ -0,0 +1,135 @@
+// bulk.go
+
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/lib/pq"
+)
+
+// bulkProductInput is one element of the POST /products/bulk payload.
+type bulkProductInput struct {
+	Name  string  `json:"name"`
+	Price float64 `json:"price"`
+}
+
+// bulkValidationError reports a single invalid element by its position in
+// the input array.
+type bulkValidationError struct {
+	Index int    `json:"index"`
+	Error string `json:"error"`
+}
+
+var errBulkPayloadNotArray = errors.New("expected a JSON array of products")
+
+// decodeBulkProducts streams a top-level JSON array from r using
+// Token/Decode, so an MB-sized payload is never held in memory as raw JSON
+// at once, and validates each element (non-empty name, price >= 0) as it's
+// read. A malformed element or truncated stream is returned as an error; a
+// well-formed but invalid element is collected in the returned
+// []bulkValidationError instead, so the caller can report every bad row in
+// one response.
+func decodeBulkProducts(r io.Reader) ([]bulkProductInput, []bulkValidationError, error) {
+	dec := json.NewDecoder(r)
+
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, nil, err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return nil, nil, errBulkPayloadNotArray
+	}
+
+	var items []bulkProductInput
+	var validationErrs []bulkValidationError
+
+	for i := 0; dec.More(); i++ {
+		var item bulkProductInput
+		if err := dec.Decode(&item); err != nil {
+			return nil, nil, err
+		}
+
+		switch {
+		case item.Name == "":
+			validationErrs = append(validationErrs, bulkValidationError{Index: i, Error: "name must not be empty"})
+		case item.Price < 0:
+			validationErrs = append(validationErrs, bulkValidationError{Index: i, Error: "price must be >= 0"})
+		default:
+			items = append(items, item)
+		}
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return nil, nil, err
+	}
+
+	return items, validationErrs, nil
+}
+
+// bulkInsertProducts inserts items inside a single transaction using
+// pq.CopyIn (COPY FROM STDIN) for throughput. Ids are reserved up front with
+// a single query that calls nextval() once per row via generate_series, then copied in
+// explicitly, so the returned ids are valid even if another session
+// allocates from the same sequence concurrently.
+func bulkInsertProducts(ctx context.Context, db *sql.DB, items []bulkProductInput) ([]int, error) {
+	if len(items) == 0 {
+		return []int{}, nil
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	idRows, err := tx.QueryContext(ctx,
+		"SELECT nextval('products_id_seq') FROM generate_series(1, $1)", len(items))
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, 0, len(items))
+	for idRows.Next() {
+		var id int
+		if err := idRows.Scan(&id); err != nil {
+			idRows.Close()
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	idRows.Close()
+	if err := idRows.Err(); err != nil {
+		return nil, err
+	}
+
+	stmt, err := tx.PrepareContext(ctx, pq.CopyIn("products", "id", "name", "price"))
+	if err != nil {
+		return nil, err
+	}
+
+	for i, item := range items {
+		if _, err := stmt.ExecContext(ctx, ids[i], item.Name, item.Price); err != nil {
+			stmt.Close()
+			return nil, err
+		}
+	}
+
+	if _, err := stmt.ExecContext(ctx); err != nil {
+		stmt.Close()
+		return nil, err
+	}
+	if err := stmt.Close(); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
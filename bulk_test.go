@@ -0,0 +1,109 @@
+// bulk_test.go
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestBulkImportProducts(t *testing.T) {
+	clearTable()
+
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for i := 0; i < 1000; i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		fmt.Fprintf(&buf, `{"name":"Bulk product %d","price":%d}`, i, i)
+	}
+	buf.WriteByte(']')
+
+	req, _ := http.NewRequest("POST", "/products/bulk", &buf)
+	req.Header.Set("Content-Type", "application/json")
+
+	response := executeRequest(req)
+	checkResponseCode(t, http.StatusCreated, response.Code)
+
+	var m map[string]interface{}
+	json.Unmarshal(response.Body.Bytes(), &m)
+
+	if m["inserted"] != 1000.0 {
+		t.Errorf("Expected 1000 products inserted. Got %v", m["inserted"])
+	}
+
+	ids, ok := m["ids"].([]interface{})
+	if !ok || len(ids) != 1000 {
+		t.Fatalf("Expected 1000 ids. Got %v", m["ids"])
+	}
+
+	var count int
+	a.DB.QueryRow("SELECT COUNT(*) FROM products").Scan(&count)
+	if count != 1000 {
+		t.Errorf("Expected 1000 rows in products. Got %d", count)
+	}
+}
+
+func TestBulkImportValidationFailureAbortsAll(t *testing.T) {
+	clearTable()
+
+	jsonStr := []byte(`[
+		{"name":"valid product", "price": 10},
+		{"name":"", "price": 5},
+		{"name":"also valid", "price": -1}
+	]`)
+
+	req, _ := http.NewRequest("POST", "/products/bulk", bytes.NewBuffer(jsonStr))
+	req.Header.Set("Content-Type", "application/json")
+
+	response := executeRequest(req)
+	checkResponseCode(t, http.StatusUnprocessableEntity, response.Code)
+
+	var m struct {
+		Errors []bulkValidationError `json:"errors"`
+	}
+	json.Unmarshal(response.Body.Bytes(), &m)
+
+	if len(m.Errors) != 2 || m.Errors[0].Index != 1 || m.Errors[1].Index != 2 {
+		t.Errorf("Expected validation errors at index 1 and 2. Got %+v", m.Errors)
+	}
+
+	var count int
+	a.DB.QueryRow("SELECT COUNT(*) FROM products").Scan(&count)
+	if count != 0 {
+		t.Errorf("Expected no rows inserted when any item fails validation. Got %d", count)
+	}
+}
+
+func TestBulkImportMalformedJSON(t *testing.T) {
+	clearTable()
+
+	jsonStr := []byte(`[{"name":"ok", "price": 1}, {"name": "broken"`)
+
+	req, _ := http.NewRequest("POST", "/products/bulk", bytes.NewBuffer(jsonStr))
+	req.Header.Set("Content-Type", "application/json")
+
+	response := executeRequest(req)
+	checkResponseCode(t, http.StatusBadRequest, response.Code)
+
+	var count int
+	a.DB.QueryRow("SELECT COUNT(*) FROM products").Scan(&count)
+	if count != 0 {
+		t.Errorf("Expected no rows inserted for malformed JSON. Got %d", count)
+	}
+}
+
+func TestBulkImportNotAnArray(t *testing.T) {
+	clearTable()
+
+	req, _ := http.NewRequest("POST", "/products/bulk", strings.NewReader(`{"name":"nope"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	response := executeRequest(req)
+	checkResponseCode(t, http.StatusBadRequest, response.Code)
+}
@@ -0,0 +1,150 @@
+// cart.go
+
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// errInsufficientStock and errCartEmpty are returned by checkoutCart when a
+// checkout can't be fulfilled.
+var (
+	errInsufficientStock = errors.New("insufficient stock")
+	errCartEmpty         = errors.New("cart is empty")
+)
+
+type cartItem struct {
+	ProductID int     `json:"product_id"`
+	Name      string  `json:"name"`
+	UnitPrice float64 `json:"unit_price"`
+	Quantity  int     `json:"quantity"`
+	Subtotal  float64 `json:"subtotal"`
+}
+
+type cart struct {
+	Items []cartItem `json:"items"`
+	Total float64    `json:"total"`
+}
+
+func getCart(ctx context.Context, db *sql.DB) (cart, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT p.id, p.name, p.price, c.quantity
+		FROM carts c JOIN products p ON p.id = c.product_id
+		ORDER BY p.id`)
+	if err != nil {
+		return cart{}, err
+	}
+	defer rows.Close()
+
+	c := cart{Items: []cartItem{}}
+	for rows.Next() {
+		var item cartItem
+		if err := rows.Scan(&item.ProductID, &item.Name, &item.UnitPrice, &item.Quantity); err != nil {
+			return cart{}, err
+		}
+		item.Subtotal = item.UnitPrice * float64(item.Quantity)
+		c.Total += item.Subtotal
+		c.Items = append(c.Items, item)
+	}
+
+	return c, rows.Err()
+}
+
+func addCartItem(ctx context.Context, db dbtx, productID, quantity int) error {
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO carts(product_id, quantity) VALUES($1, $2)
+		ON CONFLICT (product_id) DO UPDATE SET quantity = carts.quantity + EXCLUDED.quantity`,
+		productID, quantity)
+
+	return err
+}
+
+func updateCartItem(ctx context.Context, db *sql.DB, productID, quantity int) error {
+	if quantity == 0 {
+		return removeCartItem(ctx, db, productID)
+	}
+
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO carts(product_id, quantity) VALUES($1, $2)
+		ON CONFLICT (product_id) DO UPDATE SET quantity = EXCLUDED.quantity`,
+		productID, quantity)
+
+	return err
+}
+
+func removeCartItem(ctx context.Context, db *sql.DB, productID int) error {
+	_, err := db.ExecContext(ctx, "DELETE FROM carts WHERE product_id=$1", productID)
+
+	return err
+}
+
+// checkoutCart decrements stock for every cart item and empties the cart in
+// a single transaction, so a stock shortfall on one item never leaves stock
+// or the cart half-updated. It locks the matching product rows with
+// FOR UPDATE so two concurrent checkouts can't both oversell the same item.
+func checkoutCart(ctx context.Context, db *sql.DB) (cart, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return cart{}, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT p.id, p.name, p.price, p.stock, c.quantity
+		FROM carts c JOIN products p ON p.id = c.product_id
+		ORDER BY p.id
+		FOR UPDATE OF p`)
+	if err != nil {
+		return cart{}, err
+	}
+
+	type line struct {
+		item  cartItem
+		stock int
+	}
+
+	var lines []line
+	for rows.Next() {
+		var l line
+		if err := rows.Scan(&l.item.ProductID, &l.item.Name, &l.item.UnitPrice, &l.stock, &l.item.Quantity); err != nil {
+			rows.Close()
+			return cart{}, err
+		}
+		lines = append(lines, l)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return cart{}, err
+	}
+
+	if len(lines) == 0 {
+		return cart{}, errCartEmpty
+	}
+
+	receipt := cart{Items: []cartItem{}}
+	for _, l := range lines {
+		if l.item.Quantity > l.stock {
+			return cart{}, errInsufficientStock
+		}
+
+		if _, err := tx.ExecContext(ctx, "UPDATE products SET stock = stock - $1 WHERE id = $2", l.item.Quantity, l.item.ProductID); err != nil {
+			return cart{}, err
+		}
+
+		l.item.Subtotal = l.item.UnitPrice * float64(l.item.Quantity)
+		receipt.Total += l.item.Subtotal
+		receipt.Items = append(receipt.Items, l.item)
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM carts"); err != nil {
+		return cart{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return cart{}, err
+	}
+
+	return receipt, nil
+}
@@ -0,0 +1,76 @@
+// cart_service.go
+
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// errInvalidQuantity is returned by CartService.AddItem for a quantity <= 0
+// and by CartService.UpdateItem for a quantity < 0 (0 removes the line).
+var errInvalidQuantity = errors.New("quantity must be > 0 to add, >= 0 to update")
+
+// CartService implements the cart operations on top of a single *sql.DB
+// pool, mirroring the split between ProductService and model.go.
+type CartService struct {
+	DB *sql.DB
+}
+
+// NewCartService wraps db in a CartService.
+func NewCartService(db *sql.DB) *CartService {
+	return &CartService{DB: db}
+}
+
+func (s *CartService) Get(ctx context.Context) (cart, error) {
+	return getCart(ctx, s.DB)
+}
+
+// AddItem checks the product exists and upserts the cart line in a single
+// transaction, so a product deleted between the two surfaces as the
+// not-found error from getProduct rather than an FK violation from the
+// insert.
+func (s *CartService) AddItem(ctx context.Context, productID, quantity int) error {
+	if quantity <= 0 {
+		return errInvalidQuantity
+	}
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Share-lock the product row so it can't be deleted before the insert.
+	if _, err := tx.ExecContext(ctx, "SELECT 1 FROM products WHERE id=$1 FOR SHARE", productID); err != nil {
+		return err
+	}
+
+	p := product{ID: productID}
+	if err := p.getProduct(ctx, tx); err != nil {
+		return err
+	}
+
+	if err := addCartItem(ctx, tx, productID, quantity); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *CartService) UpdateItem(ctx context.Context, productID, quantity int) error {
+	if quantity < 0 {
+		return errInvalidQuantity
+	}
+
+	return updateCartItem(ctx, s.DB, productID, quantity)
+}
+
+func (s *CartService) RemoveItem(ctx context.Context, productID int) error {
+	return removeCartItem(ctx, s.DB, productID)
+}
+
+func (s *CartService) Checkout(ctx context.Context) (cart, error) {
+	return checkoutCart(ctx, s.DB)
+}
@@ -0,0 +1,161 @@
+// cart_test.go
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+func TestEmptyCart(t *testing.T) {
+	clearTable()
+
+	req, _ := http.NewRequest("GET", "/cart", nil)
+	response := executeRequest(req)
+
+	checkResponseCode(t, http.StatusOK, response.Code)
+
+	if body := response.Body.String(); body != `{"items":[],"total":0}` {
+		t.Errorf("Expected an empty cart. Got %s", body)
+	}
+}
+
+func TestAddCartItem(t *testing.T) {
+	clearTable()
+	addProducts(1)
+
+	var jsonStr = []byte(`{"product_id":1,"quantity":2}`)
+	req, _ := http.NewRequest("POST", "/cart/item", bytes.NewBuffer(jsonStr))
+	req.Header.Set("Content-Type", "application/json")
+
+	response := executeRequest(req)
+	checkResponseCode(t, http.StatusOK, response.Code)
+
+	var c cart
+	json.Unmarshal(response.Body.Bytes(), &c)
+
+	if len(c.Items) != 1 || c.Items[0].Quantity != 2 || c.Items[0].Subtotal != 20 {
+		t.Errorf("Expected one line with quantity 2 and subtotal 20. Got %+v", c)
+	}
+
+	if c.Total != 20 {
+		t.Errorf("Expected cart total 20. Got %v", c.Total)
+	}
+}
+
+func TestAddCartItemNonExistentProduct(t *testing.T) {
+	clearTable()
+
+	var jsonStr = []byte(`{"product_id":11,"quantity":1}`)
+	req, _ := http.NewRequest("POST", "/cart/item", bytes.NewBuffer(jsonStr))
+	req.Header.Set("Content-Type", "application/json")
+
+	response := executeRequest(req)
+	checkResponseCode(t, http.StatusNotFound, response.Code)
+}
+
+func TestUpdateCartItem(t *testing.T) {
+	clearTable()
+	addProducts(1)
+	addCartItem(context.Background(), a.DB, 1, 1)
+
+	var jsonStr = []byte(`{"quantity":5}`)
+	req, _ := http.NewRequest("PUT", "/cart/item/1", bytes.NewBuffer(jsonStr))
+	req.Header.Set("Content-Type", "application/json")
+
+	response := executeRequest(req)
+	checkResponseCode(t, http.StatusOK, response.Code)
+
+	var c cart
+	json.Unmarshal(response.Body.Bytes(), &c)
+
+	if len(c.Items) != 1 || c.Items[0].Quantity != 5 {
+		t.Errorf("Expected quantity to be updated to 5. Got %+v", c)
+	}
+}
+
+func TestRemoveCartItem(t *testing.T) {
+	clearTable()
+	addProducts(1)
+	addCartItem(context.Background(), a.DB, 1, 1)
+
+	req, _ := http.NewRequest("DELETE", "/cart/item/1", nil)
+	response := executeRequest(req)
+	checkResponseCode(t, http.StatusOK, response.Code)
+
+	var c cart
+	json.Unmarshal(response.Body.Bytes(), &c)
+
+	if len(c.Items) != 0 {
+		t.Errorf("Expected the cart to be empty after removal. Got %+v", c)
+	}
+}
+
+func TestCheckoutCartDecrementsStock(t *testing.T) {
+	clearTable()
+	addProducts(1)
+	addCartItem(context.Background(), a.DB, 1, 3)
+
+	req, _ := http.NewRequest("POST", "/cart/checkout", nil)
+	response := executeRequest(req)
+	checkResponseCode(t, http.StatusOK, response.Code)
+
+	var stock int
+	a.DB.QueryRow("SELECT stock FROM products WHERE id=$1", 1).Scan(&stock)
+	if stock != 97 {
+		t.Errorf("Expected stock to drop to 97. Got %d", stock)
+	}
+
+	c, err := getCart(context.Background(), a.DB)
+	if err != nil {
+		t.Fatalf("getCart: %v", err)
+	}
+	if len(c.Items) != 0 {
+		t.Errorf("Expected the cart to be empty after checkout. Got %+v", c)
+	}
+}
+
+func TestCheckoutEmptyCart(t *testing.T) {
+	clearTable()
+
+	req, _ := http.NewRequest("POST", "/cart/checkout", nil)
+	response := executeRequest(req)
+	checkResponseCode(t, http.StatusBadRequest, response.Code)
+}
+
+func TestCheckoutInsufficientStock(t *testing.T) {
+	clearTable()
+	addProducts(1)
+	addCartItem(context.Background(), a.DB, 1, 1000)
+
+	req, _ := http.NewRequest("POST", "/cart/checkout", nil)
+	response := executeRequest(req)
+	checkResponseCode(t, http.StatusConflict, response.Code)
+}
+
+func TestConcurrentAddCartItem(t *testing.T) {
+	clearTable()
+	addProducts(1)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			a.Cart.AddItem(context.Background(), 1, 1)
+		}()
+	}
+	wg.Wait()
+
+	c, err := getCart(context.Background(), a.DB)
+	if err != nil {
+		t.Fatalf("getCart: %v", err)
+	}
+	if len(c.Items) != 1 || c.Items[0].Quantity != 10 {
+		t.Errorf("Expected 10 concurrent adds to sum to quantity 10. Got %+v", c)
+	}
+}
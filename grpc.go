@@ -0,0 +1,118 @@
+// grpc.go
+
+package main
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/paulisch/go-mux/pb"
+)
+
+// grpcServer adapts ProductService to the pb.ProductServiceServer interface
+// generated from proto/product.proto. It shares the exact same *sql.DB and
+// validation as the REST handlers in app.go.
+type grpcServer struct {
+	pb.UnimplementedProductServiceServer
+	products *ProductService
+}
+
+// InitializeGRPC starts a gRPC server on addr backed by a.Products, running
+// concurrently with a.Router. The listener goroutine returns when the server
+// stops, logging any error Serve reports; callers that need a blocking call should run
+// InitializeGRPC before a.Run.
+func (a *App) InitializeGRPC(addr string) (*grpc.Server, error) {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	srv := grpc.NewServer()
+	pb.RegisterProductServiceServer(srv, &grpcServer{products: a.Products})
+
+	go func() {
+		if err := srv.Serve(lis); err != nil {
+			log.Printf("grpc server stopped serving %s: %v", addr, err)
+		}
+	}()
+
+	return srv, nil
+}
+
+func (s *grpcServer) Create(ctx context.Context, req *pb.CreateProductRequest) (*pb.Product, error) {
+	p := product{Name: req.GetName(), Price: req.GetPrice()}
+	if err := s.products.Create(ctx, &p); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return toPBProduct(p), nil
+}
+
+func (s *grpcServer) Get(ctx context.Context, req *pb.GetProductRequest) (*pb.Product, error) {
+	p, err := s.products.Get(ctx, int(req.GetId()))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Error(codes.NotFound, "product not found")
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return toPBProduct(p), nil
+}
+
+func (s *grpcServer) List(ctx context.Context, req *pb.ListProductsRequest) (*pb.ListProductsResponse, error) {
+	products, err := s.products.List(ctx, req.GetMinPrice(), req.GetMaxPrice())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &pb.ListProductsResponse{Products: make([]*pb.Product, len(products))}
+	for i, p := range products {
+		resp.Products[i] = toPBProduct(p)
+	}
+
+	return resp, nil
+}
+
+func (s *grpcServer) Update(ctx context.Context, req *pb.UpdateProductRequest) (*pb.Product, error) {
+	p := product{ID: int(req.GetId()), Name: req.GetName(), Price: req.GetPrice()}
+	if err := s.products.Update(ctx, &p); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return toPBProduct(p), nil
+}
+
+func (s *grpcServer) Delete(ctx context.Context, req *pb.DeleteProductRequest) (*pb.DeleteProductResponse, error) {
+	if err := s.products.Delete(ctx, int(req.GetId())); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.DeleteProductResponse{Result: "success"}, nil
+}
+
+func (s *grpcServer) ApplyDiscount(ctx context.Context, req *pb.ApplyDiscountRequest) (*pb.Product, error) {
+	p, err := s.products.ApplyDiscount(ctx, int(req.GetId()), req.GetDiscount())
+	if err != nil {
+		switch err {
+		case ErrInvalidDiscount:
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case sql.ErrNoRows:
+			return nil, status.Error(codes.NotFound, "product not found")
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	return toPBProduct(p), nil
+}
+
+func toPBProduct(p product) *pb.Product {
+	return &pb.Product{Id: int32(p.ID), Name: p.Name, Price: p.Price}
+}
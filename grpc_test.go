@@ -0,0 +1,104 @@
+// grpc_test.go
+
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/paulisch/go-mux/pb"
+)
+
+func dialGRPC(t *testing.T) pb.ProductServiceClient {
+	lis := bufconn.Listen(1024 * 1024)
+
+	srv := grpc.NewServer()
+	pb.RegisterProductServiceServer(srv, &grpcServer{products: a.Products})
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("dialing bufconn: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return pb.NewProductServiceClient(conn)
+}
+
+func TestGRPCCreateAndGetProduct(t *testing.T) {
+	clearTable()
+	client := dialGRPC(t)
+	ctx := context.Background()
+
+	created, err := client.Create(ctx, &pb.CreateProductRequest{Name: "test product", Price: 11.22})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	if created.Name != "test product" || created.Price != 11.22 {
+		t.Errorf("Expected created product {test product, 11.22}. Got {%s, %v}", created.Name, created.Price)
+	}
+
+	got, err := client.Get(ctx, &pb.GetProductRequest{Id: created.Id})
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	if got.Name != created.Name || got.Price != created.Price {
+		t.Errorf("Expected Get to return the created product. Got {%s, %v}", got.Name, got.Price)
+	}
+}
+
+func TestGRPCListFilterPrice(t *testing.T) {
+	clearTable()
+	addProducts(10)
+	client := dialGRPC(t)
+
+	resp, err := client.List(context.Background(), &pb.ListProductsRequest{MinPrice: 60, MaxPrice: 70})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+
+	if len(resp.Products) != 2 || resp.Products[0].Price != 60 || resp.Products[1].Price != 70 {
+		t.Errorf("Expected products priced 60 and 70. Got %v", resp.Products)
+	}
+}
+
+func TestGRPCApplyDiscount(t *testing.T) {
+	clearTable()
+	addProducts(1)
+	client := dialGRPC(t)
+
+	discounted, err := client.ApplyDiscount(context.Background(), &pb.ApplyDiscountRequest{Id: 1, Discount: 25})
+	if err != nil {
+		t.Fatalf("ApplyDiscount: %v", err)
+	}
+
+	if discounted.Price != 7.5 {
+		t.Errorf("Expected discounted price 7.5. Got %v", discounted.Price)
+	}
+}
+
+func TestGRPCApplyDiscountInvalid(t *testing.T) {
+	clearTable()
+	addProducts(1)
+	client := dialGRPC(t)
+
+	if _, err := client.ApplyDiscount(context.Background(), &pb.ApplyDiscountRequest{Id: 1, Discount: -1}); err == nil {
+		t.Error("Expected an error for a discount below 0")
+	}
+
+	if _, err := client.ApplyDiscount(context.Background(), &pb.ApplyDiscountRequest{Id: 1, Discount: 101}); err == nil {
+		t.Error("Expected an error for a discount above 100")
+	}
+}
@@ -25,6 +25,7 @@ func TestMain(m *testing.M) {
 		os.Getenv("APP_DB_NAME"))
 
 	ensureTableExists()
+	ensureCartTableExists()
 	code := m.Run()
 	clearTable()
 	os.Exit(code)
@@ -36,9 +37,17 @@ func ensureTableExists() {
 	}
 }
 
+func ensureCartTableExists() {
+	if _, err := a.DB.Exec(cartTableCreationQuery); err != nil {
+		log.Fatal(err)
+	}
+}
+
 func clearTable() {
+	a.DB.Exec("DELETE FROM carts")
 	a.DB.Exec("DELETE FROM products")
 	a.DB.Exec("ALTER SEQUENCE products_id_seq RESTART WITH 1")
+	a.DB.Exec("ALTER SEQUENCE carts_id_seq RESTART WITH 1")
 }
 
 const tableCreationQuery = `CREATE TABLE IF NOT EXISTS products
@@ -46,9 +55,19 @@ const tableCreationQuery = `CREATE TABLE IF NOT EXISTS products
     id SERIAL,
     name TEXT NOT NULL,
     price NUMERIC(10,2) NOT NULL DEFAULT 0.00,
+    stock INTEGER NOT NULL DEFAULT 0,
     CONSTRAINT products_pkey PRIMARY KEY (id)
 )`
 
+const cartTableCreationQuery = `CREATE TABLE IF NOT EXISTS carts
+(
+    id SERIAL,
+    product_id INTEGER NOT NULL REFERENCES products(id),
+    quantity INTEGER NOT NULL,
+    CONSTRAINT carts_pkey PRIMARY KEY (id),
+    CONSTRAINT carts_product_id_unique UNIQUE (product_id)
+)`
+
 func TestEmptyTable(t *testing.T) {
 	clearTable()
 
@@ -57,8 +76,8 @@ func TestEmptyTable(t *testing.T) {
 
 	checkResponseCode(t, http.StatusOK, response.Code)
 
-	if body := response.Body.String(); body != "[]" {
-		t.Errorf("Expected an empty array. Got %s", body)
+	if body := response.Body.String(); body != `{"data":[],"next_cursor":""}` {
+		t.Errorf("Expected an empty page. Got %s", body)
 	}
 }
 
@@ -71,8 +90,9 @@ func TestFilterPrice(t *testing.T) {
 
 	checkResponseCode(t, http.StatusOK, response.Code)
 
-	if body := response.Body.String(); body != "[{\"id\":6,\"name\":\"Product 5\",\"price\":60},{\"id\":7,\"name\":\"Product 6\",\"price\":70}]" {
-		t.Errorf("Expected '[{\"id\":6,\"name\":\"Product 5\",\"price\":60},{\"id\":7,\"name\":\"Product 6\",\"price\":70}]'. Got %s", body)
+	expected := `{"data":[{"id":6,"name":"Product 5","price":60},{"id":7,"name":"Product 6","price":70}],"next_cursor":""}`
+	if body := response.Body.String(); body != expected {
+		t.Errorf("Expected %s. Got %s", expected, body)
 	}
 }
 
@@ -158,7 +178,7 @@ func addProducts(count int) {
 	}
 
 	for i := 0; i < count; i++ {
-		a.DB.Exec("INSERT INTO products(name, price) VALUES($1, $2)", "Product "+strconv.Itoa(i), (i+1.0)*10)
+		a.DB.Exec("INSERT INTO products(name, price, stock) VALUES($1, $2, $3)", "Product "+strconv.Itoa(i), (i+1.0)*10, 100)
 	}
 }
 
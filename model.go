@@ -0,0 +1,87 @@
+// model.go
+
+package main
+
+import (
+	"context"
+	"database/sql"
+)
+
+type product struct {
+	ID    int     `json:"id"`
+	Name  string  `json:"name"`
+	Price float64 `json:"price"`
+}
+
+// dbtx is satisfied by both *sql.DB and *sql.Tx. getProduct is written
+// against it, rather than *sql.DB directly, so a caller that needs to check
+// a product exists as part of a larger transaction (see CartService.AddItem)
+// can run it against a *sql.Tx instead of a separate round-trip.
+type dbtx interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+func (p *product) getProduct(ctx context.Context, db dbtx) error {
+	return db.QueryRowContext(ctx, "SELECT name, price FROM products WHERE id=$1",
+		p.ID).Scan(&p.Name, &p.Price)
+}
+
+func (p *product) updateProduct(ctx context.Context, db *sql.DB) error {
+	_, err := db.ExecContext(ctx, "UPDATE products SET name=$1, price=$2 WHERE id=$3",
+		p.Name, p.Price, p.ID)
+
+	return err
+}
+
+func (p *product) deleteProduct(ctx context.Context, db *sql.DB) error {
+	_, err := db.ExecContext(ctx, "DELETE FROM products WHERE id=$1", p.ID)
+
+	return err
+}
+
+func (p *product) createProduct(ctx context.Context, db *sql.DB) error {
+	err := db.QueryRowContext(ctx,
+		"INSERT INTO products(name, price) VALUES($1, $2) RETURNING id",
+		p.Name, p.Price).Scan(&p.ID)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *product) discountProduct(ctx context.Context, db *sql.DB, discount float64) error {
+	newPrice := p.Price * (1 - discount/100)
+
+	_, err := db.ExecContext(ctx, "UPDATE products SET price=$1 WHERE id=$2", newPrice, p.ID)
+	if err != nil {
+		return err
+	}
+
+	p.Price = newPrice
+	return nil
+}
+
+func getProducts(ctx context.Context, db *sql.DB, minPrice, maxPrice float64) ([]product, error) {
+	rows, err := db.QueryContext(ctx,
+		"SELECT id, name, price FROM products WHERE price >= $1 AND price <= $2 ORDER BY id",
+		minPrice, maxPrice)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	products := []product{}
+
+	for rows.Next() {
+		var p product
+		if err := rows.Scan(&p.ID, &p.Name, &p.Price); err != nil {
+			return nil, err
+		}
+		products = append(products, p)
+	}
+
+	return products, rows.Err()
+}
@@ -0,0 +1,161 @@
+// product_query.go
+
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidCursor is returned by decodeProductCursor when a cursor is
+// malformed, or was issued for a different sort than the current request.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+var productSortColumns = map[string]string{
+	"id":    "id",
+	"name":  "name",
+	"price": "price",
+}
+
+const defaultProductPageLimit = 20
+const maxProductPageLimit = 100
+
+// productListParams bundles the /products query parameters that shape a
+// page: filters (MinPrice/MaxPrice/NameLike), ordering (Sort/Order) and
+// keyset pagination (Limit/Cursor).
+type productListParams struct {
+	MinPrice float64
+	MaxPrice float64
+	NameLike string
+	Sort     string
+	Order    string
+	Limit    int
+	Cursor   *productCursor
+}
+
+// productCursor is the opaque keyset cursor returned as next_cursor: the
+// (sort, value, id) of the last row on a page, so the next page can resume
+// with a WHERE (value, id) > (...) clause that stays stable even if rows
+// are inserted in between pages.
+type productCursor struct {
+	Sort  string      `json:"sort"`
+	Value interface{} `json:"value"`
+	ID    int         `json:"id"`
+}
+
+func encodeProductCursor(c productCursor) (string, error) {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.URLEncoding.EncodeToString(b), nil
+}
+
+// decodeProductCursor decodes cursor produced by encodeProductCursor. An
+// empty cursor is the first page and returns (nil, nil). A cursor that
+// doesn't decode, or was issued for a different sort, is rejected as
+// ErrInvalidCursor rather than silently reinterpreted.
+func decodeProductCursor(encoded, sort string) (*productCursor, error) {
+	if encoded == "" {
+		return nil, nil
+	}
+
+	b, err := base64.URLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var c productCursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	if c.Sort != sort {
+		return nil, ErrInvalidCursor
+	}
+
+	return &c, nil
+}
+
+// productPage is one page of products plus the cursor to fetch the next
+// one. NextCursor is empty once the result set is exhausted.
+type productPage struct {
+	Data       []product
+	NextCursor string
+}
+
+// getProductsPage runs a filtered, sorted, keyset-paginated product query.
+// It requests Limit+1 rows so it can tell whether a next page exists without
+// a separate COUNT query, then trims the extra row before returning.
+func getProductsPage(ctx context.Context, db *sql.DB, params productListParams) (productPage, error) {
+	column, ok := productSortColumns[params.Sort]
+	if !ok {
+		column, params.Sort = "id", "id"
+	}
+
+	dir, cmp := "ASC", ">"
+	if params.Order == "desc" {
+		dir, cmp = "DESC", "<"
+	}
+
+	query := `SELECT id, name, price FROM products WHERE price >= $1 AND price <= $2 AND name ILIKE $3`
+	args := []interface{}{params.MinPrice, params.MaxPrice, "%" + params.NameLike + "%"}
+
+	if params.Cursor != nil {
+		query += fmt.Sprintf(" AND (%s %s $%d OR (%s = $%d AND id %s $%d))",
+			column, cmp, len(args)+1, column, len(args)+1, cmp, len(args)+2)
+		args = append(args, params.Cursor.Value, params.Cursor.ID)
+	}
+
+	query += fmt.Sprintf(" ORDER BY %s %s, id %s LIMIT $%d", column, dir, dir, len(args)+1)
+	args = append(args, params.Limit+1)
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return productPage{}, err
+	}
+	defer rows.Close()
+
+	products := []product{}
+	for rows.Next() {
+		var p product
+		if err := rows.Scan(&p.ID, &p.Name, &p.Price); err != nil {
+			return productPage{}, err
+		}
+		products = append(products, p)
+	}
+	if err := rows.Err(); err != nil {
+		return productPage{}, err
+	}
+
+	page := productPage{Data: products}
+	if len(products) <= params.Limit {
+		return page, nil
+	}
+
+	last := products[params.Limit-1]
+	page.Data = products[:params.Limit]
+
+	var sortValue interface{}
+	switch params.Sort {
+	case "name":
+		sortValue = last.Name
+	case "price":
+		sortValue = last.Price
+	default:
+		sortValue = last.ID
+	}
+
+	nextCursor, err := encodeProductCursor(productCursor{Sort: params.Sort, Value: sortValue, ID: last.ID})
+	if err != nil {
+		return productPage{}, err
+	}
+	page.NextCursor = nextCursor
+
+	return page, nil
+}
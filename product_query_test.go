@@ -0,0 +1,90 @@
+// product_query_test.go
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestProductsPaginationStableAcrossPages(t *testing.T) {
+	clearTable()
+	addProducts(100)
+
+	seen := make(map[int]bool)
+	cursor := ""
+
+	for {
+		url := "/products?sort=id&order=asc&limit=10"
+		if cursor != "" {
+			url += "&cursor=" + cursor
+		}
+
+		req, _ := http.NewRequest("GET", url, nil)
+		response := executeRequest(req)
+		checkResponseCode(t, http.StatusOK, response.Code)
+
+		var page struct {
+			Data       []map[string]interface{} `json:"data"`
+			NextCursor string                   `json:"next_cursor"`
+		}
+		json.Unmarshal(response.Body.Bytes(), &page)
+
+		if len(page.Data) == 0 {
+			t.Fatal("Expected a non-empty page before next_cursor runs dry")
+		}
+
+		for _, row := range page.Data {
+			id := int(row["id"].(float64))
+			if seen[id] {
+				t.Fatalf("product %d seen twice across pages", id)
+			}
+			seen[id] = true
+		}
+
+		if page.NextCursor == "" {
+			break
+		}
+		cursor = page.NextCursor
+	}
+
+	if len(seen) != 100 {
+		t.Errorf("Expected to see all 100 products across pages. Got %d", len(seen))
+	}
+}
+
+func TestProductsInvalidCursor(t *testing.T) {
+	clearTable()
+	addProducts(5)
+
+	req, _ := http.NewRequest("GET", "/products?cursor=not-valid-base64!!", nil)
+	response := executeRequest(req)
+
+	checkResponseCode(t, http.StatusBadRequest, response.Code)
+
+	var m map[string]string
+	json.Unmarshal(response.Body.Bytes(), &m)
+	if m["error"] != "Invalid cursor" {
+		t.Errorf("Expected the 'error' key of the response to be set to 'Invalid cursor'. Got '%s'", m["error"])
+	}
+}
+
+func TestProductsCursorForWrongSortRejected(t *testing.T) {
+	clearTable()
+	addProducts(30)
+
+	req, _ := http.NewRequest("GET", "/products?sort=id&order=asc&limit=10", nil)
+	response := executeRequest(req)
+	checkResponseCode(t, http.StatusOK, response.Code)
+
+	var page struct {
+		NextCursor string `json:"next_cursor"`
+	}
+	json.Unmarshal(response.Body.Bytes(), &page)
+
+	req, _ = http.NewRequest("GET", "/products?sort=price&order=asc&limit=10&cursor="+page.NextCursor, nil)
+	response = executeRequest(req)
+
+	checkResponseCode(t, http.StatusBadRequest, response.Code)
+}
@@ -0,0 +1,89 @@
+// product_service.go
+
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// ErrInvalidDiscount is returned by ProductService.ApplyDiscount when the
+// requested discount falls outside the 0-100 range.
+var ErrInvalidDiscount = errors.New("discount must be >= 0 and <= 100")
+
+// ProductService implements the product operations (create, get, list,
+// update, delete, discount) on top of a single *sql.DB pool. It holds no
+// transport-specific state so the REST handlers in app.go and the gRPC
+// server in grpc.go can share the exact same validation and queries.
+type ProductService struct {
+	DB *sql.DB
+}
+
+// NewProductService wraps db in a ProductService.
+func NewProductService(db *sql.DB) *ProductService {
+	return &ProductService{DB: db}
+}
+
+func (s *ProductService) Create(ctx context.Context, p *product) error {
+	return p.createProduct(ctx, s.DB)
+}
+
+func (s *ProductService) Get(ctx context.Context, id int) (product, error) {
+	p := product{ID: id}
+	err := p.getProduct(ctx, s.DB)
+	return p, err
+}
+
+func (s *ProductService) List(ctx context.Context, minPrice, maxPrice float64) ([]product, error) {
+	return getProducts(ctx, s.DB, minPrice, maxPrice)
+}
+
+// ListPage runs a filtered, sorted, keyset-paginated product query. Limit is
+// clamped to (0, maxProductPageLimit], defaulting to defaultProductPageLimit.
+func (s *ProductService) ListPage(ctx context.Context, params productListParams) (productPage, error) {
+	if params.Limit <= 0 {
+		params.Limit = defaultProductPageLimit
+	}
+	if params.Limit > maxProductPageLimit {
+		params.Limit = maxProductPageLimit
+	}
+
+	return getProductsPage(ctx, s.DB, params)
+}
+
+func (s *ProductService) Update(ctx context.Context, p *product) error {
+	return p.updateProduct(ctx, s.DB)
+}
+
+func (s *ProductService) Delete(ctx context.Context, id int) error {
+	p := product{ID: id}
+	return p.deleteProduct(ctx, s.DB)
+}
+
+// BulkCreate inserts items in a single transaction via pq.CopyIn and returns
+// the id assigned to each, in input order. Callers are expected to have
+// already validated items (see decodeBulkProducts).
+func (s *ProductService) BulkCreate(ctx context.Context, items []bulkProductInput) ([]int, error) {
+	return bulkInsertProducts(ctx, s.DB, items)
+}
+
+// ApplyDiscount looks up the product and reduces its price by discount
+// percent. discount must be between 0 and 100 inclusive; this is the single
+// place that rule is enforced, so every transport gets it for free.
+func (s *ProductService) ApplyDiscount(ctx context.Context, id int, discount float64) (product, error) {
+	if discount < 0 || discount > 100 {
+		return product{}, ErrInvalidDiscount
+	}
+
+	p := product{ID: id}
+	if err := p.getProduct(ctx, s.DB); err != nil {
+		return product{}, err
+	}
+
+	if err := p.discountProduct(ctx, s.DB, discount); err != nil {
+		return product{}, err
+	}
+
+	return p, nil
+}
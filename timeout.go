@@ -0,0 +1,105 @@
+// timeout.go
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"sync"
+)
+
+// timeoutResponseWriter buffers a handler's response instead of writing it
+// straight to the real ResponseWriter. handlerTimeoutMiddleware runs the
+// handler in its own goroutine so it can race a timeout; if the timeout
+// wins, the handler may still be running (e.g. unwinding a canceled
+// QueryContext) and must not touch the ResponseWriter the timeout path
+// already responded on. Buffering here, and only ever flushing to the real
+// ResponseWriter from the goroutine that owns it, is what net/http's own
+// TimeoutHandler does to avoid exactly that race.
+type timeoutResponseWriter struct {
+	mu          sync.Mutex
+	header      http.Header
+	buf         bytes.Buffer
+	status      int
+	wroteHeader bool
+}
+
+func newTimeoutResponseWriter() *timeoutResponseWriter {
+	return &timeoutResponseWriter{header: make(http.Header)}
+}
+
+func (w *timeoutResponseWriter) Header() http.Header {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.header
+}
+
+func (w *timeoutResponseWriter) WriteHeader(status int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.wroteHeader {
+		return
+	}
+	w.status = status
+	w.wroteHeader = true
+}
+
+func (w *timeoutResponseWriter) Write(b []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.wroteHeader {
+		w.status = http.StatusOK
+		w.wroteHeader = true
+	}
+	return w.buf.Write(b)
+}
+
+// flushTo copies the buffered response into real. Only called after the
+// handler has returned, so it never overlaps with the handler's own writes.
+func (w *timeoutResponseWriter) flushTo(real http.ResponseWriter) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	dst := real.Header()
+	for k, v := range w.header {
+		dst[k] = v
+	}
+
+	if w.wroteHeader {
+		real.WriteHeader(w.status)
+	}
+	real.Write(w.buf.Bytes())
+}
+
+// handlerTimeoutMiddleware bounds how long a handler may run for. The
+// handler runs against a buffered ResponseWriter and a context derived from
+// a.HandlerTimeout; if that context expires first, the client gets a 503
+// and the buffer is simply never flushed, so whatever the handler
+// eventually writes (e.g. after a canceled QueryContext unwinds) is
+// discarded instead of racing the response already sent.
+func (a *App) handlerTimeoutMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if a.HandlerTimeout <= 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), a.HandlerTimeout)
+		defer cancel()
+
+		buffered := newTimeoutResponseWriter()
+		done := make(chan struct{})
+		go func() {
+			next.ServeHTTP(buffered, r.WithContext(ctx))
+			close(done)
+		}()
+
+		select {
+		case <-done:
+			buffered.flushTo(w)
+		case <-ctx.Done():
+			respondWithError(w, http.StatusServiceUnavailable, "Request timed out")
+		}
+	})
+}
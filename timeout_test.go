@@ -0,0 +1,63 @@
+// timeout_test.go
+
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"testing"
+)
+
+// slowHandler blocks until its context is done, simulating a slow PostgreSQL
+// query hung behind pg_sleep, then attempts to respond anyway - mirroring a
+// real handler that finishes unwinding a canceled QueryContext after the
+// middleware has already given up on it and answered the client. wroteLate
+// is closed once that late write has happened, so the test can wait for it
+// deterministically instead of racing it.
+func slowHandler(wroteLate chan<- struct{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		respondWithJSON(w, http.StatusOK, map[string]string{"status": "too late"})
+		close(wroteLate)
+	}
+}
+
+func TestHandlerTimeoutReturns503(t *testing.T) {
+	app := App{HandlerTimeout: 20 * time.Millisecond}
+	rr := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/slow", nil)
+
+	wroteLate := make(chan struct{})
+	app.handlerTimeoutMiddleware(slowHandler(wroteLate)).ServeHTTP(rr, req)
+
+	checkResponseCode(t, http.StatusServiceUnavailable, rr.Code)
+
+	select {
+	case <-wroteLate:
+	case <-time.After(time.Second):
+		t.Fatal("handler never attempted its late write")
+	}
+
+	// The late write landed in the middleware's buffer, not the
+	// ResponseWriter the client already got a response on, so the 503
+	// should be untouched.
+	checkResponseCode(t, http.StatusServiceUnavailable, rr.Code)
+	if body := rr.Body.String(); !strings.Contains(body, "Request timed out") {
+		t.Errorf("expected the 503 body to survive the handler's late write, got %q", body)
+	}
+}
+
+func TestHandlerTimeoutDisabledByDefault(t *testing.T) {
+	app := App{}
+	rr := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/fast", nil)
+
+	app.handlerTimeoutMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})).ServeHTTP(rr, req)
+
+	checkResponseCode(t, http.StatusOK, rr.Code)
+}